@@ -0,0 +1,35 @@
+package pgtype
+
+// BinarySizer is implemented by element transcoders that can report the
+// exact number of bytes their EncodeBinary call will write to w. Array
+// encoders use this to compute the wire format payload length up front so
+// they can stream the header and each element straight to w instead of
+// buffering the whole array just to measure it. Element types that can't
+// cheaply predict their encoded size (most variable-length text-backed
+// types) simply don't implement it, and array encoders fall back to
+// buffering for those.
+type BinarySizer interface {
+	BinarySize() int
+}
+
+// arrayHeaderBinarySize returns the number of bytes ArrayHeader.EncodeBinary
+// writes for the given dimensions: ndims, hasnull, and elemtype (4 bytes
+// each), plus a (length, lower bound) pair (8 bytes) per dimension.
+func arrayHeaderBinarySize(dimensions []ArrayDimension) int {
+	return 12 + 8*len(dimensions)
+}
+
+// binarySizeOfElements returns the total encoded size of elements and
+// whether every element reported a size. ok is false as soon as one element
+// doesn't implement BinarySizer, signaling the caller to fall back to
+// buffering.
+func binarySizeOfElements(elements []ValueTranscoder) (size int, ok bool) {
+	for _, elem := range elements {
+		sizer, isSizer := elem.(BinarySizer)
+		if !isSizer {
+			return 0, false
+		}
+		size += sizer.BinarySize()
+	}
+	return size, true
+}
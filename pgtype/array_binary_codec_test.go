@@ -0,0 +1,44 @@
+package pgtype
+
+import "testing"
+
+func TestArrayHeaderBinarySize(t *testing.T) {
+	if got := arrayHeaderBinarySize(nil); got != 12 {
+		t.Fatalf("arrayHeaderBinarySize(nil) = %d, want 12", got)
+	}
+
+	dims := []ArrayDimension{{Length: 3, LowerBound: 1}, {Length: 2, LowerBound: 1}}
+	if got := arrayHeaderBinarySize(dims); got != 28 {
+		t.Fatalf("arrayHeaderBinarySize(2 dims) = %d, want 28", got)
+	}
+}
+
+func TestBinarySizeOfElements(t *testing.T) {
+	elements := []ValueTranscoder{&Int4{Int: 1, Status: Present}, &Int4{Status: Null}}
+
+	size, ok := binarySizeOfElements(elements)
+	if !ok {
+		t.Fatal("binarySizeOfElements() ok = false, want true")
+	}
+	if want := 8 + 4; size != want {
+		t.Fatalf("binarySizeOfElements() = %d, want %d", size, want)
+	}
+}
+
+func TestInt4BinarySize(t *testing.T) {
+	if got := (&Int4{Int: 1, Status: Present}).BinarySize(); got != 8 {
+		t.Fatalf("BinarySize() = %d, want 8", got)
+	}
+	if got := (&Int4{Status: Null}).BinarySize(); got != 4 {
+		t.Fatalf("BinarySize() = %d, want 4", got)
+	}
+}
+
+func TestTextBinarySize(t *testing.T) {
+	if got := (&Text{String: "hi", Status: Present}).BinarySize(); got != 6 {
+		t.Fatalf("BinarySize() = %d, want 6", got)
+	}
+	if got := (&Text{Status: Null}).BinarySize(); got != 4 {
+		t.Fatalf("BinarySize() = %d, want 4", got)
+	}
+}
@@ -0,0 +1,76 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/pgio"
+)
+
+// textTranscoder is satisfied by every array type in this package. It lets
+// arrayDriverValue and arrayDriverScan implement database/sql's
+// driver.Valuer and sql.Scanner once, in terms of the EncodeText/DecodeText
+// methods each array type already has. EncodeText/DecodeText speak the pgx
+// wire format, which is the array's text literal (e.g. "{1,2,3}") prefixed
+// with a 4 byte length; arrayDriverValue/arrayDriverScan add and strip that
+// prefix so database/sql and its drivers only ever see the plain literal.
+type textTranscoder interface {
+	EncodeText(w io.Writer) error
+	DecodeText(r io.Reader) error
+}
+
+// arrayDriverValue encodes src as its plain Postgres array literal text
+// (e.g. "{1,2,3}", or nil for a Null array) so array types can be passed to
+// database/sql's db.Query/db.Exec with any driver, not only pgx.
+func arrayDriverValue(src textTranscoder) (driver.Value, error) {
+	buf := &bytes.Buffer{}
+	if err := src.EncodeText(buf); err != nil {
+		return nil, err
+	}
+
+	size, err := pgio.ReadInt32(bytes.NewReader(buf.Bytes()[:4]))
+	if err != nil {
+		return nil, err
+	}
+	if size == -1 {
+		return nil, nil
+	}
+
+	return buf.Bytes()[4:], nil
+}
+
+// arrayDriverScan is the Scan counterpart of arrayDriverValue: it accepts
+// the plain array literal text (or bytes) a database/sql driver returned
+// for a column, or nil for SQL NULL, and feeds it through DecodeText by
+// re-adding the length prefix DecodeText expects.
+func arrayDriverScan(dst textTranscoder, src interface{}) error {
+	var text []byte
+	switch v := src.(type) {
+	case nil:
+		text = nil
+	case []byte:
+		text = v
+	case string:
+		text = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, dst)
+	}
+
+	framed := &bytes.Buffer{}
+	if src == nil {
+		if _, err := pgio.WriteInt32(framed, -1); err != nil {
+			return err
+		}
+	} else {
+		if _, err := pgio.WriteInt32(framed, int32(len(text))); err != nil {
+			return err
+		}
+		if _, err := framed.Write(text); err != nil {
+			return err
+		}
+	}
+
+	return dst.DecodeText(framed)
+}
@@ -0,0 +1,151 @@
+package pgtype
+
+import (
+	"net"
+	"testing"
+)
+
+func TestInetArrayValueScanRoundTrip(t *testing.T) {
+	var src InetArray
+	if err := src.ConvertFrom([]net.IP{net.IPv4(127, 0, 0, 1)}); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+
+	value, err := src.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	text, ok := value.([]byte)
+	if !ok {
+		t.Fatalf("Value() = %T, want []byte", value)
+	}
+	if len(text) == 0 || text[0] != '{' {
+		t.Fatalf("Value() = %q, want a plain array literal with no wire framing", text)
+	}
+
+	var dst InetArray
+	if err := dst.Scan(text); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if len(dst.Elements) != 1 {
+		t.Fatalf("Scan() produced %d elements, want 1", len(dst.Elements))
+	}
+}
+
+func TestInetArrayScanDriverText(t *testing.T) {
+	// A real database/sql driver returns the column's plain text, with no
+	// wire-format length prefix.
+	var dst InetArray
+	if err := dst.Scan("{127.0.0.1/32}"); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if len(dst.Elements) != 1 {
+		t.Fatalf("Scan() produced %d elements, want 1", len(dst.Elements))
+	}
+}
+
+func TestInetArrayValueScanNull(t *testing.T) {
+	src := InetArray{Status: Null}
+
+	value, err := src.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	if value != nil {
+		t.Fatalf("Value() = %v, want nil", value)
+	}
+
+	var dst InetArray
+	if err := dst.Scan(nil); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if dst.Status != Null {
+		t.Fatalf("Scan(nil) Status = %v, want Null", dst.Status)
+	}
+}
+
+func TestArrayTypeValueScanRoundTrip(t *testing.T) {
+	src := NewArrayType("_int4", Int4OID, func() ValueTranscoder { return &Int4{} })
+	if err := src.Set([]int32{1, 2, 3}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	value, err := src.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	text, ok := value.([]byte)
+	if !ok {
+		t.Fatalf("Value() = %T, want []byte", value)
+	}
+	if len(text) == 0 || text[0] != '{' {
+		t.Fatalf("Value() = %q, want a plain array literal with no wire framing", text)
+	}
+
+	dst := NewArrayType("_int4", Int4OID, func() ValueTranscoder { return &Int4{} })
+	if err := dst.Scan(text); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	var got []int32
+	if err := dst.AssignTo(&got); err != nil {
+		t.Fatalf("AssignTo: %v", err)
+	}
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+}
+
+func TestMultirangeValueScanRoundTrip(t *testing.T) {
+	src := Multirange[int32]{Status: Present}
+
+	value, err := src.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	text, ok := value.([]byte)
+	if !ok {
+		t.Fatalf("Value() = %T, want []byte", value)
+	}
+	if string(text) != "{}" {
+		t.Fatalf("Value() = %q, want %q", text, "{}")
+	}
+
+	var dst Multirange[int32]
+	if err := dst.Scan(text); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if dst.Status != Present {
+		t.Fatalf("Scan() Status = %v, want Present", dst.Status)
+	}
+}
+
+func TestMultirangeArrayValueScanRoundTrip(t *testing.T) {
+	src := MultirangeArray[int32]{Status: Present}
+
+	value, err := src.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	text, ok := value.([]byte)
+	if !ok {
+		t.Fatalf("Value() = %T, want []byte", value)
+	}
+	if string(text) != "{}" {
+		t.Fatalf("Value() = %q, want %q", text, "{}")
+	}
+
+	var dst MultirangeArray[int32]
+	if err := dst.Scan(text); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if dst.Status != Present {
+		t.Fatalf("Scan() Status = %v, want Present", dst.Status)
+	}
+}
@@ -0,0 +1,359 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/jackc/pgx/pgio"
+)
+
+// ArrayType is a generic implementation of a PostgreSQL array type for some
+// element type that implements ValueTranscoder. It allows callers to
+// register array support for custom domains, enums, and extension types
+// (e.g. PostGIS, ltree) at runtime without having to hand-write an
+// XxxArray type like InetArray.
+type ArrayType struct {
+	elements   []ValueTranscoder
+	dimensions []ArrayDimension
+	status     Status
+
+	typeName   string
+	elementOID uint32
+	newElement func() ValueTranscoder
+}
+
+// NewArrayType returns a new ArrayType for elements of the type produced by
+// newElement. typeName is returned by TypeName, which RegisterArrayType uses
+// as the registered type name, and appears in error messages. elementOID is the
+// OID of the scalar element type (e.g. the OID of a custom enum or domain),
+// not the OID of the array type itself.
+func NewArrayType(typeName string, elementOID uint32, newElement func() ValueTranscoder) *ArrayType {
+	return &ArrayType{
+		typeName:   typeName,
+		elementOID: elementOID,
+		newElement: newElement,
+	}
+}
+
+func (dst *ArrayType) NewTypeValue() Value {
+	return &ArrayType{
+		typeName:   dst.typeName,
+		elementOID: dst.elementOID,
+		newElement: dst.newElement,
+	}
+}
+
+func (dst *ArrayType) TypeName() string {
+	return dst.typeName
+}
+
+func (dst *ArrayType) Set(src interface{}) error {
+	if src == nil {
+		*dst = ArrayType{typeName: dst.typeName, elementOID: dst.elementOID, newElement: dst.newElement, status: Null}
+		return nil
+	}
+
+	value := reflect.ValueOf(src)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot convert %v to %s: not a slice", src, dst.typeName)
+	}
+
+	if value.IsNil() {
+		*dst = ArrayType{typeName: dst.typeName, elementOID: dst.elementOID, newElement: dst.newElement, status: Null}
+		return nil
+	}
+
+	elements := make([]ValueTranscoder, value.Len())
+	for i := range elements {
+		elem := dst.newElement()
+		if err := elem.ConvertFrom(value.Index(i).Interface()); err != nil {
+			return fmt.Errorf("cannot convert %v to %s: %w", src, dst.typeName, err)
+		}
+		elements[i] = elem
+	}
+
+	*dst = ArrayType{
+		elements:   elements,
+		dimensions: []ArrayDimension{{Length: int32(len(elements)), LowerBound: 1}},
+		status:     Present,
+		typeName:   dst.typeName,
+		elementOID: dst.elementOID,
+		newElement: dst.newElement,
+	}
+
+	return nil
+}
+
+func (dst *ArrayType) Get() interface{} {
+	switch dst.status {
+	case Present:
+		return dst.elements
+	case Null:
+		return nil
+	default:
+		return dst.status
+	}
+}
+
+func (src *ArrayType) AssignTo(dst interface{}) error {
+	ptrValue := reflect.ValueOf(dst)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("cannot assign %s to %T: not a pointer to a slice", src.typeName, dst)
+	}
+
+	if src.status == Null {
+		ptrValue.Elem().Set(reflect.Zero(ptrValue.Elem().Type()))
+		return nil
+	}
+
+	sliceType := ptrValue.Elem().Type()
+	slice := reflect.MakeSlice(sliceType, len(src.elements), len(src.elements))
+	for i := range src.elements {
+		if err := src.elements[i].AssignTo(slice.Index(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("cannot assign %s to %T: %w", src.typeName, dst, err)
+		}
+	}
+	ptrValue.Elem().Set(slice)
+
+	return nil
+}
+
+func (dst *ArrayType) DecodeText(r io.Reader) error {
+	size, err := pgio.ReadInt32(r)
+	if err != nil {
+		return err
+	}
+
+	if size == -1 {
+		*dst = ArrayType{typeName: dst.typeName, elementOID: dst.elementOID, newElement: dst.newElement, status: Null}
+		return nil
+	}
+
+	buf := make([]byte, int(size))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+
+	uta, err := ParseUntypedTextArray(string(buf))
+	if err != nil {
+		return err
+	}
+
+	textElementReader := NewTextElementReader(r)
+	var elements []ValueTranscoder
+
+	if len(uta.Elements) > 0 {
+		elements = make([]ValueTranscoder, len(uta.Elements))
+
+		for i, s := range uta.Elements {
+			elem := dst.newElement()
+			textElementReader.Reset(s)
+			if err := elem.DecodeText(textElementReader); err != nil {
+				return err
+			}
+			elements[i] = elem
+		}
+	}
+
+	*dst = ArrayType{
+		elements:   elements,
+		dimensions: uta.Dimensions,
+		status:     Present,
+		typeName:   dst.typeName,
+		elementOID: dst.elementOID,
+		newElement: dst.newElement,
+	}
+
+	return nil
+}
+
+func (dst *ArrayType) DecodeBinary(r io.Reader) error {
+	size, err := pgio.ReadInt32(r)
+	if err != nil {
+		return err
+	}
+
+	if size == -1 {
+		*dst = ArrayType{typeName: dst.typeName, elementOID: dst.elementOID, newElement: dst.newElement, status: Null}
+		return nil
+	}
+
+	lr := &io.LimitedReader{R: r, N: int64(size)}
+
+	var arrayHeader ArrayHeader
+	if err := arrayHeader.DecodeBinary(lr); err != nil {
+		return err
+	}
+
+	if len(arrayHeader.Dimensions) == 0 {
+		*dst = ArrayType{dimensions: arrayHeader.Dimensions, status: Present, typeName: dst.typeName, elementOID: dst.elementOID, newElement: dst.newElement}
+		return nil
+	}
+
+	elementCount := arrayHeader.Dimensions[0].Length
+	for _, d := range arrayHeader.Dimensions[1:] {
+		elementCount *= d.Length
+	}
+
+	elements := make([]ValueTranscoder, elementCount)
+	for i := range elements {
+		elem := dst.newElement()
+		if err := elem.DecodeBinary(lr); err != nil {
+			return err
+		}
+		elements[i] = elem
+	}
+
+	*dst = ArrayType{
+		elements:   elements,
+		dimensions: arrayHeader.Dimensions,
+		status:     Present,
+		typeName:   dst.typeName,
+		elementOID: dst.elementOID,
+		newElement: dst.newElement,
+	}
+
+	return nil
+}
+
+func (src *ArrayType) EncodeText(w io.Writer) error {
+	if done, err := encodeNotPresent(w, src.status); done {
+		return err
+	}
+
+	if len(src.dimensions) == 0 {
+		if _, err := pgio.WriteInt32(w, 2); err != nil {
+			return err
+		}
+		_, err := w.Write([]byte("{}"))
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := EncodeTextArrayDimensions(buf, src.dimensions); err != nil {
+		return err
+	}
+
+	dimElemCounts := make([]int, len(src.dimensions))
+	dimElemCounts[len(src.dimensions)-1] = int(src.dimensions[len(src.dimensions)-1].Length)
+	for i := len(src.dimensions) - 2; i > -1; i-- {
+		dimElemCounts[i] = int(src.dimensions[i].Length) * dimElemCounts[i+1]
+	}
+
+	textElementWriter := NewTextElementWriter(buf)
+
+	for i, elem := range src.elements {
+		if i > 0 {
+			if err := pgio.WriteByte(buf, ','); err != nil {
+				return err
+			}
+		}
+
+		for _, dec := range dimElemCounts {
+			if i%dec == 0 {
+				if err := pgio.WriteByte(buf, '{'); err != nil {
+					return err
+				}
+			}
+		}
+
+		textElementWriter.Reset()
+		if err := elem.EncodeText(textElementWriter); err != nil {
+			return err
+		}
+
+		for _, dec := range dimElemCounts {
+			if (i+1)%dec == 0 {
+				if err := pgio.WriteByte(buf, '}'); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if _, err := pgio.WriteInt32(w, int32(buf.Len())); err != nil {
+		return err
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func (src *ArrayType) EncodeBinary(w io.Writer) error {
+	if done, err := encodeNotPresent(w, src.status); done {
+		return err
+	}
+
+	containsNull := false
+	for i := range src.elements {
+		if src.elements[i].Get() == nil {
+			containsNull = true
+		}
+	}
+
+	arrayHeader := ArrayHeader{
+		ContainsNull: containsNull,
+		ElementOID:   int32(src.elementOID),
+		Dimensions:   src.dimensions,
+	}
+
+	if elemSize, ok := binarySizeOfElements(src.elements); ok {
+		payloadSize := arrayHeaderBinarySize(src.dimensions) + elemSize
+		if _, err := pgio.WriteInt32(w, int32(payloadSize)); err != nil {
+			return err
+		}
+
+		if err := arrayHeader.EncodeBinary(w); err != nil {
+			return err
+		}
+
+		for i := range src.elements {
+			if err := src.elements[i].EncodeBinary(w); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Fall back to buffering when an element can't report its encoded size
+	// up front.
+	elemBuf := &bytes.Buffer{}
+	for i := range src.elements {
+		if err := src.elements[i].EncodeBinary(elemBuf); err != nil {
+			return err
+		}
+	}
+
+	headerBuf := &bytes.Buffer{}
+	if err := arrayHeader.EncodeBinary(headerBuf); err != nil {
+		return err
+	}
+
+	if _, err := pgio.WriteInt32(w, int32(headerBuf.Len()+elemBuf.Len())); err != nil {
+		return err
+	}
+
+	if _, err := headerBuf.WriteTo(w); err != nil {
+		return err
+	}
+
+	_, err := elemBuf.WriteTo(w)
+	return err
+}
+
+// Value implements the database/sql/driver Valuer interface. This lets any
+// database/sql driver, not just pgx, bind a generic ArrayType parameter by
+// its plain text array literal.
+func (src ArrayType) Value() (driver.Value, error) {
+	return arrayDriverValue(&src)
+}
+
+// Scan implements the database/sql Scanner interface.
+func (dst *ArrayType) Scan(src interface{}) error {
+	return arrayDriverScan(dst, src)
+}
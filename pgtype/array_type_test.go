@@ -0,0 +1,32 @@
+package pgtype
+
+import "testing"
+
+func TestArrayTypeSetAssignTo(t *testing.T) {
+	at := NewArrayType("_int4", Int4OID, func() ValueTranscoder { return &Int4{} })
+
+	if err := at.Set([]int32{1, 2, 3}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	var got []int32
+	if err := at.AssignTo(&got); err != nil {
+		t.Fatalf("AssignTo: %v", err)
+	}
+
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+}
+
+func TestArrayTypeSetNil(t *testing.T) {
+	at := NewArrayType("_int4", Int4OID, func() ValueTranscoder { return &Int4{} })
+
+	if err := at.Set(nil); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if at.Get() != nil {
+		t.Fatalf("Get() = %v, want nil", at.Get())
+	}
+}
@@ -0,0 +1,13 @@
+package pgtype
+
+// RegisterArrayType associates arrayOID with an ArrayType value so that rows
+// and parameters using arrayOID are transcoded with t. This allows callers to
+// add array support for custom domains, enums, and extension types (e.g.
+// PostGIS, ltree) without a compiled-in XxxArray type.
+func (ci *ConnInfo) RegisterArrayType(arrayOID uint32, t *ArrayType) {
+	ci.RegisterDataType(DataType{
+		Value: t,
+		Name:  t.TypeName(),
+		OID:   arrayOID,
+	})
+}
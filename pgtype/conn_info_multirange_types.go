@@ -0,0 +1,27 @@
+package pgtype
+
+// Standard OIDs for the multirange types PostgreSQL 14+ ships out of the
+// box.
+const (
+	Int4multirangeOID = 4451
+	Int8multirangeOID = 4536
+	NummultirangeOID  = 4532
+	TsmultirangeOID   = 4533
+	TstzmultirangeOID = 4534
+	DatemultirangeOID = 4535
+)
+
+// RegisterDefaultPgTypeMultirangeTypes registers the standard PostgreSQL
+// multirange types with ci, so that e.g. `SELECT $1::int4multirange` round
+// trips through a Multirange[int32]. Unlike the scalar and array types a
+// ConnInfo already knows about on construction, multirange support is
+// opt-in: callers must call this once on their ConnInfo, e.g. right after
+// connecting, before querying or sending multirange columns.
+func (ci *ConnInfo) RegisterDefaultPgTypeMultirangeTypes() {
+	ci.RegisterDataType(DataType{Value: &Multirange[int32]{}, Name: "int4multirange", OID: Int4multirangeOID})
+	ci.RegisterDataType(DataType{Value: &Multirange[int64]{}, Name: "int8multirange", OID: Int8multirangeOID})
+	ci.RegisterDataType(DataType{Value: &Multirange[Numeric]{}, Name: "nummultirange", OID: NummultirangeOID})
+	ci.RegisterDataType(DataType{Value: &Multirange[Timestamp]{}, Name: "tsmultirange", OID: TsmultirangeOID})
+	ci.RegisterDataType(DataType{Value: &Multirange[Timestamptz]{}, Name: "tstzmultirange", OID: TstzmultirangeOID})
+	ci.RegisterDataType(DataType{Value: &Multirange[Date]{}, Name: "datemultirange", OID: DatemultirangeOID})
+}
@@ -0,0 +1,17 @@
+package pgtype
+
+// BinarySize returns the number of bytes EncodeBinary will write: the 4 byte
+// length prefix, plus, when present, a 4 byte family/bits/is_cidr/length
+// header and the 4 or 16 address bytes. It lets InetArray compute a binary
+// array's total payload size without encoding every element first.
+func (src *Inet) BinarySize() int {
+	if src.Status != Present {
+		return 4
+	}
+
+	if ip4 := src.IPNet.IP.To4(); ip4 != nil {
+		return 4 + 4 + len(ip4)
+	}
+
+	return 4 + 4 + len(src.IPNet.IP)
+}
@@ -2,6 +2,7 @@ package pgtype
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"fmt"
 	"io"
 	"net"
@@ -125,6 +126,9 @@ func (dst *InetArray) DecodeText(r io.Reader) error {
 		return err
 	}
 
+	ctx := startArrayDecode(readerContext(r), InetOID, len(uta.Dimensions), len(uta.Elements))
+	defer func() { endArrayDecode(ctx, err) }()
+
 	textElementReader := NewTextElementReader(r)
 	var elements []Inet
 
@@ -159,8 +163,10 @@ func (dst *InetArray) DecodeBinary(r io.Reader) error {
 		return nil
 	}
 
+	lr := &io.LimitedReader{R: r, N: int64(size)}
+
 	var arrayHeader ArrayHeader
-	err = arrayHeader.DecodeBinary(r)
+	err = arrayHeader.DecodeBinary(lr)
 	if err != nil {
 		return err
 	}
@@ -175,10 +181,13 @@ func (dst *InetArray) DecodeBinary(r io.Reader) error {
 		elementCount *= d.Length
 	}
 
+	ctx := startArrayDecode(readerContext(r), InetOID, len(arrayHeader.Dimensions), int(elementCount))
+	defer func() { endArrayDecode(ctx, err) }()
+
 	elements := make([]Inet, elementCount)
 
 	for i := range elements {
-		err = elements[i].DecodeBinary(r)
+		err = elements[i].DecodeBinary(lr)
 		if err != nil {
 			return err
 		}
@@ -188,14 +197,16 @@ func (dst *InetArray) DecodeBinary(r io.Reader) error {
 	return nil
 }
 
-func (src *InetArray) EncodeText(w io.Writer) error {
+func (src *InetArray) EncodeText(w io.Writer) (err error) {
 	if done, err := encodeNotPresent(w, src.Status); done {
 		return err
 	}
 
+	ctx := startArrayEncode(writerContext(w), InetOID, len(src.Dimensions), len(src.Elements))
+	defer func() { endArrayEncode(ctx, err) }()
+
 	if len(src.Dimensions) == 0 {
-		_, err := pgio.WriteInt32(w, 2)
-		if err != nil {
+		if _, err = pgio.WriteInt32(w, 2); err != nil {
 			return err
 		}
 
@@ -205,7 +216,7 @@ func (src *InetArray) EncodeText(w io.Writer) error {
 
 	buf := &bytes.Buffer{}
 
-	err := EncodeTextArrayDimensions(buf, src.Dimensions)
+	err = EncodeTextArrayDimensions(buf, src.Dimensions)
 	if err != nil {
 		return err
 	}
@@ -269,52 +280,84 @@ func (src *InetArray) EncodeBinary(w io.Writer) error {
 	return src.encodeBinary(w, InetOID)
 }
 
-func (src *InetArray) encodeBinary(w io.Writer, elementOID int32) error {
+func (src *InetArray) encodeBinary(w io.Writer, elementOID int32) (err error) {
 	if done, err := encodeNotPresent(w, src.Status); done {
 		return err
 	}
 
-	var arrayHeader ArrayHeader
-
-	// TODO - consider how to avoid having to buffer array before writing length -
-	// or how not pay allocations for the byte order conversions.
-	elemBuf := &bytes.Buffer{}
+	ctx := startArrayEncode(writerContext(w), uint32(elementOID), len(src.Dimensions), len(src.Elements))
+	defer func() { endArrayEncode(ctx, err) }()
 
+	containsNull := false
+	elements := make([]ValueTranscoder, len(src.Elements))
 	for i := range src.Elements {
-		err := src.Elements[i].EncodeBinary(elemBuf)
-		if err != nil {
+		if src.Elements[i].Status == Null {
+			containsNull = true
+		}
+		elements[i] = &src.Elements[i]
+	}
+
+	arrayHeader := ArrayHeader{
+		ContainsNull: containsNull,
+		ElementOID:   elementOID,
+		Dimensions:   src.Dimensions,
+	}
+
+	if elemSize, ok := binarySizeOfElements(elements); ok {
+		payloadSize := arrayHeaderBinarySize(src.Dimensions) + elemSize
+		if _, err = pgio.WriteInt32(w, int32(payloadSize)); err != nil {
 			return err
 		}
-		if src.Elements[i].Status == Null {
-			arrayHeader.ContainsNull = true
+
+		if err = arrayHeader.EncodeBinary(w); err != nil {
+			return err
+		}
+
+		for i := range src.Elements {
+			if err = src.Elements[i].EncodeBinary(w); err != nil {
+				return err
+			}
 		}
+
+		return nil
 	}
 
-	arrayHeader.ElementOID = elementOID
-	arrayHeader.Dimensions = src.Dimensions
+	// Fall back to buffering the whole array to compute the payload length
+	// when an element can't report its encoded size up front. Every *Inet,
+	// Null ones included, implements BinarySizer, so InetArray values take
+	// the streaming path above.
+	elemBuf := &bytes.Buffer{}
+	for i := range src.Elements {
+		if err = src.Elements[i].EncodeBinary(elemBuf); err != nil {
+			return err
+		}
+	}
 
-	// TODO - consider how to avoid having to buffer array before writing length -
-	// or how not pay allocations for the byte order conversions.
 	headerBuf := &bytes.Buffer{}
-	err := arrayHeader.EncodeBinary(headerBuf)
-	if err != nil {
+	if err = arrayHeader.EncodeBinary(headerBuf); err != nil {
 		return err
 	}
 
-	_, err = pgio.WriteInt32(w, int32(headerBuf.Len()+elemBuf.Len()))
-	if err != nil {
+	if _, err = pgio.WriteInt32(w, int32(headerBuf.Len()+elemBuf.Len())); err != nil {
 		return err
 	}
 
-	_, err = headerBuf.WriteTo(w)
-	if err != nil {
+	if _, err = headerBuf.WriteTo(w); err != nil {
 		return err
 	}
 
 	_, err = elemBuf.WriteTo(w)
-	if err != nil {
-		return err
-	}
-
 	return err
 }
+
+// Value implements the database/sql/driver Valuer interface, allowing
+// InetArray to be passed to database/sql's db.Query/db.Exec with any
+// driver, not only pgx.
+func (src InetArray) Value() (driver.Value, error) {
+	return arrayDriverValue(&src)
+}
+
+// Scan implements the database/sql Scanner interface.
+func (dst *InetArray) Scan(src interface{}) error {
+	return arrayDriverScan(dst, src)
+}
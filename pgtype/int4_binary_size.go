@@ -0,0 +1,13 @@
+package pgtype
+
+// BinarySize returns the number of bytes EncodeBinary will write: the 4
+// byte length prefix, plus, when present, the 4 byte int32 value. It lets
+// ArrayType compute a binary array's total payload size without encoding
+// every element first.
+func (src *Int4) BinarySize() int {
+	if src.Status != Present {
+		return 4
+	}
+
+	return 4 + 4
+}
@@ -0,0 +1,257 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jackc/pgx/pgio"
+)
+
+// Multirange is a generic transcoder for a PostgreSQL multirange of T, such
+// as int4multirange or tstzmultirange (PostgreSQL 14+). It mirrors
+// InetArray's shape (Text/Binary encode/decode, ConvertFrom, AssignTo) but
+// holds a flat list of ranges rather than an N-dimensional array of
+// scalars, matching the multirange wire format.
+type Multirange[T any] struct {
+	Ranges []Range[T]
+	Status Status
+}
+
+func (dst *Multirange[T]) ConvertFrom(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*dst = Multirange[T]{Status: Null}
+	case Multirange[T]:
+		*dst = value
+	case []Range[T]:
+		if value == nil {
+			*dst = Multirange[T]{Status: Null}
+		} else {
+			*dst = Multirange[T]{Ranges: value, Status: Present}
+		}
+	default:
+		if originalSrc, ok := underlyingSliceType(src); ok {
+			return dst.ConvertFrom(originalSrc)
+		}
+		return fmt.Errorf("cannot convert %v to Multirange", value)
+	}
+
+	return nil
+}
+
+func (src *Multirange[T]) AssignTo(dst interface{}) error {
+	switch v := dst.(type) {
+	case *[]Range[T]:
+		if src.Status == Present {
+			*v = src.Ranges
+		} else {
+			*v = nil
+		}
+	default:
+		if originalDst, ok := underlyingPtrSliceType(dst); ok {
+			return src.AssignTo(originalDst)
+		}
+		return fmt.Errorf("cannot put decode %v into %T", src, dst)
+	}
+
+	return nil
+}
+
+func (dst *Multirange[T]) Set(src interface{}) error {
+	return dst.ConvertFrom(src)
+}
+
+func (dst *Multirange[T]) Get() interface{} {
+	switch dst.Status {
+	case Present:
+		return dst.Ranges
+	case Null:
+		return nil
+	default:
+		return dst.Status
+	}
+}
+
+func (dst *Multirange[T]) DecodeText(r io.Reader) error {
+	size, err := pgio.ReadInt32(r)
+	if err != nil {
+		return err
+	}
+
+	if size == -1 {
+		*dst = Multirange[T]{Status: Null}
+		return nil
+	}
+
+	buf := make([]byte, int(size))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+
+	elementStrings, err := splitUntypedTextMultirange(string(buf))
+	if err != nil {
+		return err
+	}
+
+	textElementReader := NewTextElementReader(r)
+	var ranges []Range[T]
+
+	if len(elementStrings) > 0 {
+		ranges = make([]Range[T], len(elementStrings))
+
+		for i, s := range elementStrings {
+			var elem Range[T]
+			textElementReader.Reset(s)
+			if err := elem.DecodeText(textElementReader); err != nil {
+				return err
+			}
+			ranges[i] = elem
+		}
+	}
+
+	*dst = Multirange[T]{Ranges: ranges, Status: Present}
+
+	return nil
+}
+
+func (dst *Multirange[T]) DecodeBinary(r io.Reader) error {
+	size, err := pgio.ReadInt32(r)
+	if err != nil {
+		return err
+	}
+
+	if size == -1 {
+		*dst = Multirange[T]{Status: Null}
+		return nil
+	}
+
+	lr := &io.LimitedReader{R: r, N: int64(size)}
+
+	rangeCount, err := pgio.ReadInt32(lr)
+	if err != nil {
+		return err
+	}
+
+	ranges := make([]Range[T], rangeCount)
+	for i := range ranges {
+		if err := ranges[i].DecodeBinary(lr); err != nil {
+			return err
+		}
+	}
+
+	*dst = Multirange[T]{Ranges: ranges, Status: Present}
+
+	return nil
+}
+
+func (src *Multirange[T]) EncodeText(w io.Writer) error {
+	if done, err := encodeNotPresent(w, src.Status); done {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := pgio.WriteByte(buf, '{'); err != nil {
+		return err
+	}
+
+	textElementWriter := NewTextElementWriter(buf)
+
+	for i := range src.Ranges {
+		if i > 0 {
+			if err := pgio.WriteByte(buf, ','); err != nil {
+				return err
+			}
+		}
+
+		textElementWriter.Reset()
+		if err := src.Ranges[i].EncodeText(textElementWriter); err != nil {
+			return err
+		}
+	}
+
+	if err := pgio.WriteByte(buf, '}'); err != nil {
+		return err
+	}
+
+	if _, err := pgio.WriteInt32(w, int32(buf.Len())); err != nil {
+		return err
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func (src *Multirange[T]) EncodeBinary(w io.Writer) error {
+	if done, err := encodeNotPresent(w, src.Status); done {
+		return err
+	}
+
+	elemBuf := &bytes.Buffer{}
+	if _, err := pgio.WriteInt32(elemBuf, int32(len(src.Ranges))); err != nil {
+		return err
+	}
+
+	for i := range src.Ranges {
+		if err := src.Ranges[i].EncodeBinary(elemBuf); err != nil {
+			return err
+		}
+	}
+
+	if _, err := pgio.WriteInt32(w, int32(elemBuf.Len())); err != nil {
+		return err
+	}
+
+	_, err := elemBuf.WriteTo(w)
+	return err
+}
+
+// splitUntypedTextMultirange splits a multirange's text representation, e.g.
+// "{[1,3),[5,6)}", into its individual range strings. Unlike array text,
+// multirange text has no dimension prefix and its elements are never
+// quoted, so a simple split that tracks range bracket depth is enough.
+func splitUntypedTextMultirange(src string) ([]string, error) {
+	src = strings.TrimSpace(src)
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return nil, fmt.Errorf("invalid multirange: %v", src)
+	}
+
+	inner := src[1 : len(src)-1]
+	if inner == "" {
+		return nil, nil
+	}
+
+	var elements []string
+	depth := 0
+	start := 0
+	for i, b := range inner {
+		switch b {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				elements = append(elements, inner[start:i])
+				start = i + 1
+			}
+		}
+	}
+	elements = append(elements, inner[start:])
+
+	return elements, nil
+}
+
+// Value implements the database/sql/driver Valuer interface, encoding src
+// as the PostgreSQL multirange text literal so it can be bound by any
+// database/sql driver.
+func (src Multirange[T]) Value() (driver.Value, error) {
+	return arrayDriverValue(&src)
+}
+
+// Scan implements the database/sql Scanner interface.
+func (dst *Multirange[T]) Scan(src interface{}) error {
+	return arrayDriverScan(dst, src)
+}
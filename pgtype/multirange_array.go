@@ -0,0 +1,301 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/pgio"
+)
+
+// MultirangeArray is a generic transcoder for an array of Multirange[T], such
+// as int4multirange[]. It mirrors InetArray, substituting Multirange[T]
+// elements for Inet ones.
+type MultirangeArray[T any] struct {
+	Elements   []Multirange[T]
+	Dimensions []ArrayDimension
+	Status     Status
+
+	// ElementOID is the OID of the multirange type (e.g. int4multirange),
+	// not the array type itself. It must be set before EncodeBinary is
+	// called on a value that wasn't produced by DecodeBinary.
+	ElementOID int32
+}
+
+func (dst *MultirangeArray[T]) ConvertFrom(src interface{}) error {
+	switch value := src.(type) {
+	case MultirangeArray[T]:
+		*dst = value
+	case [][]Range[T]:
+		if value == nil {
+			*dst = MultirangeArray[T]{Status: Null}
+		} else if len(value) == 0 {
+			*dst = MultirangeArray[T]{Status: Present}
+		} else {
+			elements := make([]Multirange[T], len(value))
+			for i := range value {
+				if err := elements[i].ConvertFrom(value[i]); err != nil {
+					return err
+				}
+			}
+			*dst = MultirangeArray[T]{
+				Elements:   elements,
+				Dimensions: []ArrayDimension{{Length: int32(len(elements)), LowerBound: 1}},
+				Status:     Present,
+			}
+		}
+	default:
+		if originalSrc, ok := underlyingSliceType(src); ok {
+			return dst.ConvertFrom(originalSrc)
+		}
+		return fmt.Errorf("cannot convert %v to MultirangeArray", value)
+	}
+
+	return nil
+}
+
+func (src *MultirangeArray[T]) AssignTo(dst interface{}) error {
+	switch v := dst.(type) {
+	case *[][]Range[T]:
+		if src.Status == Present {
+			*v = make([][]Range[T], len(src.Elements))
+			for i := range src.Elements {
+				if err := src.Elements[i].AssignTo(&((*v)[i])); err != nil {
+					return err
+				}
+			}
+		} else {
+			*v = nil
+		}
+	default:
+		if originalDst, ok := underlyingPtrSliceType(dst); ok {
+			return src.AssignTo(originalDst)
+		}
+		return fmt.Errorf("cannot put decode %v into %T", src, dst)
+	}
+
+	return nil
+}
+
+func (dst *MultirangeArray[T]) DecodeText(r io.Reader) error {
+	size, err := pgio.ReadInt32(r)
+	if err != nil {
+		return err
+	}
+
+	if size == -1 {
+		*dst = MultirangeArray[T]{Status: Null}
+		return nil
+	}
+
+	buf := make([]byte, int(size))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+
+	uta, err := ParseUntypedTextArray(string(buf))
+	if err != nil {
+		return err
+	}
+
+	textElementReader := NewTextElementReader(r)
+	var elements []Multirange[T]
+
+	if len(uta.Elements) > 0 {
+		elements = make([]Multirange[T], len(uta.Elements))
+
+		for i, s := range uta.Elements {
+			var elem Multirange[T]
+			textElementReader.Reset(s)
+			if err := elem.DecodeText(textElementReader); err != nil {
+				return err
+			}
+			elements[i] = elem
+		}
+	}
+
+	*dst = MultirangeArray[T]{Elements: elements, Dimensions: uta.Dimensions, Status: Present}
+
+	return nil
+}
+
+func (dst *MultirangeArray[T]) DecodeBinary(r io.Reader) error {
+	size, err := pgio.ReadInt32(r)
+	if err != nil {
+		return err
+	}
+
+	if size == -1 {
+		*dst = MultirangeArray[T]{Status: Null}
+		return nil
+	}
+
+	lr := &io.LimitedReader{R: r, N: int64(size)}
+
+	var arrayHeader ArrayHeader
+	if err := arrayHeader.DecodeBinary(lr); err != nil {
+		return err
+	}
+
+	if len(arrayHeader.Dimensions) == 0 {
+		*dst = MultirangeArray[T]{Dimensions: arrayHeader.Dimensions, Status: Present}
+		return nil
+	}
+
+	elementCount := arrayHeader.Dimensions[0].Length
+	for _, d := range arrayHeader.Dimensions[1:] {
+		elementCount *= d.Length
+	}
+
+	elements := make([]Multirange[T], elementCount)
+	for i := range elements {
+		if err := elements[i].DecodeBinary(lr); err != nil {
+			return err
+		}
+	}
+
+	*dst = MultirangeArray[T]{Elements: elements, Dimensions: arrayHeader.Dimensions, Status: Present, ElementOID: arrayHeader.ElementOID}
+	return nil
+}
+
+func (src *MultirangeArray[T]) EncodeText(w io.Writer) error {
+	if done, err := encodeNotPresent(w, src.Status); done {
+		return err
+	}
+
+	if len(src.Dimensions) == 0 {
+		if _, err := pgio.WriteInt32(w, 2); err != nil {
+			return err
+		}
+		_, err := w.Write([]byte("{}"))
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := EncodeTextArrayDimensions(buf, src.Dimensions); err != nil {
+		return err
+	}
+
+	dimElemCounts := make([]int, len(src.Dimensions))
+	dimElemCounts[len(src.Dimensions)-1] = int(src.Dimensions[len(src.Dimensions)-1].Length)
+	for i := len(src.Dimensions) - 2; i > -1; i-- {
+		dimElemCounts[i] = int(src.Dimensions[i].Length) * dimElemCounts[i+1]
+	}
+
+	textElementWriter := NewTextElementWriter(buf)
+
+	for i := range src.Elements {
+		if i > 0 {
+			if err := pgio.WriteByte(buf, ','); err != nil {
+				return err
+			}
+		}
+
+		for _, dec := range dimElemCounts {
+			if i%dec == 0 {
+				if err := pgio.WriteByte(buf, '{'); err != nil {
+					return err
+				}
+			}
+		}
+
+		textElementWriter.Reset()
+		if err := src.Elements[i].EncodeText(textElementWriter); err != nil {
+			return err
+		}
+
+		for _, dec := range dimElemCounts {
+			if (i+1)%dec == 0 {
+				if err := pgio.WriteByte(buf, '}'); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if _, err := pgio.WriteInt32(w, int32(buf.Len())); err != nil {
+		return err
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func (src *MultirangeArray[T]) EncodeBinary(w io.Writer) error {
+	if done, err := encodeNotPresent(w, src.Status); done {
+		return err
+	}
+
+	containsNull := false
+	elements := make([]ValueTranscoder, len(src.Elements))
+	for i := range src.Elements {
+		if src.Elements[i].Status == Null {
+			containsNull = true
+		}
+		elements[i] = &src.Elements[i]
+	}
+
+	arrayHeader := ArrayHeader{
+		ContainsNull: containsNull,
+		ElementOID:   src.ElementOID,
+		Dimensions:   src.Dimensions,
+	}
+
+	if elemSize, ok := binarySizeOfElements(elements); ok {
+		payloadSize := arrayHeaderBinarySize(src.Dimensions) + elemSize
+		if _, err := pgio.WriteInt32(w, int32(payloadSize)); err != nil {
+			return err
+		}
+
+		if err := arrayHeader.EncodeBinary(w); err != nil {
+			return err
+		}
+
+		for i := range src.Elements {
+			if err := src.Elements[i].EncodeBinary(w); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Fall back to buffering when an element can't report its encoded size
+	// up front.
+	elemBuf := &bytes.Buffer{}
+	for i := range src.Elements {
+		if err := src.Elements[i].EncodeBinary(elemBuf); err != nil {
+			return err
+		}
+	}
+
+	headerBuf := &bytes.Buffer{}
+	if err := arrayHeader.EncodeBinary(headerBuf); err != nil {
+		return err
+	}
+
+	if _, err := pgio.WriteInt32(w, int32(headerBuf.Len()+elemBuf.Len())); err != nil {
+		return err
+	}
+
+	if _, err := headerBuf.WriteTo(w); err != nil {
+		return err
+	}
+
+	_, err := elemBuf.WriteTo(w)
+	return err
+}
+
+// Value implements the database/sql/driver Valuer interface, giving
+// drivers other than pgx the plain text literal for this array of
+// multiranges.
+func (src MultirangeArray[T]) Value() (driver.Value, error) {
+	return arrayDriverValue(&src)
+}
+
+// Scan implements the database/sql Scanner interface.
+func (dst *MultirangeArray[T]) Scan(src interface{}) error {
+	return arrayDriverScan(dst, src)
+}
@@ -0,0 +1,64 @@
+package pgtype
+
+import "testing"
+
+func TestMultirangeSetGet(t *testing.T) {
+	var m Multirange[int32]
+
+	ranges := []Range[int32]{{}, {}}
+	if err := m.Set(ranges); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, ok := m.Get().([]Range[int32])
+	if !ok {
+		t.Fatalf("Get() = %#v, want []Range[int32]", m.Get())
+	}
+	if len(got) != len(ranges) {
+		t.Fatalf("Get() = %v, want %v", got, ranges)
+	}
+}
+
+func TestMultirangeSetGetNil(t *testing.T) {
+	var m Multirange[int32]
+
+	if err := m.Set(nil); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if m.Get() != nil {
+		t.Fatalf("Get() = %v, want nil", m.Get())
+	}
+}
+
+func TestSplitUntypedTextMultirange(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string
+	}{
+		{"{}", nil},
+		{"{[1,3)}", []string{"[1,3)"}},
+		{"{[1,3),[5,6)}", []string{"[1,3)", "[5,6)"}},
+	}
+
+	for _, tt := range tests {
+		got, err := splitUntypedTextMultirange(tt.src)
+		if err != nil {
+			t.Fatalf("splitUntypedTextMultirange(%q): %v", tt.src, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("splitUntypedTextMultirange(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("splitUntypedTextMultirange(%q) = %v, want %v", tt.src, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSplitUntypedTextMultirangeInvalid(t *testing.T) {
+	if _, err := splitUntypedTextMultirange("[1,3)"); err == nil {
+		t.Fatal("expected error for missing braces")
+	}
+}
@@ -0,0 +1,69 @@
+// Package otelpgtype implements pgtype.Tracer with OpenTelemetry spans, so
+// operators can see per-element array encode/decode cost and array size
+// alongside the query span produced by higher-level libraries.
+package otelpgtype
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/jackc/pgx/pgtype/otelpgtype"
+
+// Tracer implements pgtype.Tracer, recording each array decode or encode as
+// a span named "pgtype.array.decode"/"pgtype.array.encode" with
+// db.pgtype.array.length, db.pgtype.array.dimensions, and
+// db.pgtype.array.element_oid attributes.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a Tracer that creates spans with the given
+// trace.TracerProvider, or the global provider if tp is nil.
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &Tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+func (t *Tracer) start(ctx context.Context, name string, elementOID uint32, dimensions, elementCount int) context.Context {
+	ctx, _ = t.tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.Int64("db.pgtype.array.length", int64(elementCount)),
+		attribute.Int64("db.pgtype.array.dimensions", int64(dimensions)),
+		attribute.Int64("db.pgtype.array.element_oid", int64(elementOID)),
+	))
+	return ctx
+}
+
+func end(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *Tracer) StartArrayDecode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context {
+	return t.start(ctx, "pgtype.array.decode", elementOID, dimensions, elementCount)
+}
+
+func (t *Tracer) EndArrayDecode(ctx context.Context, err error) {
+	end(ctx, err)
+}
+
+func (t *Tracer) StartArrayEncode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context {
+	return t.start(ctx, "pgtype.array.encode", elementOID, dimensions, elementCount)
+}
+
+func (t *Tracer) EndArrayEncode(ctx context.Context, err error) {
+	end(ctx, err)
+}
+
+var _ pgtype.Tracer = (*Tracer)(nil)
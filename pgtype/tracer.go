@@ -0,0 +1,103 @@
+package pgtype
+
+import (
+	"context"
+	"io"
+)
+
+// Tracer lets operators observe per-element array encode/decode cost and
+// array size alongside the query span produced by higher-level libraries.
+// An implementation is registered package-wide with SetTracer; see the
+// otelpgtype subpackage for an OpenTelemetry-backed one.
+type Tracer interface {
+	// StartArrayDecode is called before an array type starts decoding
+	// elementCount elements, arranged in dimensions dimensions, of the
+	// scalar type identified by elementOID. The returned context is passed
+	// to the matching EndArrayDecode call.
+	StartArrayDecode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context
+
+	// EndArrayDecode is called after an array decode finishes, err being
+	// the error it finished with, if any.
+	EndArrayDecode(ctx context.Context, err error)
+
+	// StartArrayEncode is the EncodeText/EncodeBinary counterpart of
+	// StartArrayDecode.
+	StartArrayEncode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context
+
+	// EndArrayEncode is the EncodeText/EncodeBinary counterpart of
+	// EndArrayDecode.
+	EndArrayEncode(ctx context.Context, err error)
+}
+
+var tracer Tracer
+
+// SetTracer registers t as the package-wide Tracer. Passing nil disables
+// tracing; array encode/decode methods are a no-op with respect to tracing
+// until a Tracer is set.
+func SetTracer(t Tracer) {
+	tracer = t
+}
+
+// ContextReader is implemented by an io.Reader that can supply the context
+// the decode call reading from it should run under. Callers that want array
+// decode spans nested under their own in-flight query span should pass a
+// reader implementing this instead of a plain io.Reader; DecodeText and
+// DecodeBinary fall back to context.Background() otherwise.
+type ContextReader interface {
+	io.Reader
+	Context() context.Context
+}
+
+// ContextWriter is the EncodeText/EncodeBinary counterpart of ContextReader.
+type ContextWriter interface {
+	io.Writer
+	Context() context.Context
+}
+
+func readerContext(r io.Reader) context.Context {
+	if cr, ok := r.(ContextReader); ok {
+		return cr.Context()
+	}
+	return context.Background()
+}
+
+func writerContext(w io.Writer) context.Context {
+	if cw, ok := w.(ContextWriter); ok {
+		return cw.Context()
+	}
+	return context.Background()
+}
+
+// startArrayDecode is a convenience wrapper so call sites don't have to
+// nil-check tracer themselves.
+func startArrayDecode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context {
+	if tracer == nil {
+		return ctx
+	}
+	return tracer.StartArrayDecode(ctx, elementOID, dimensions, elementCount)
+}
+
+// endArrayDecode is the EndArrayDecode counterpart of startArrayDecode.
+func endArrayDecode(ctx context.Context, err error) {
+	if tracer == nil {
+		return
+	}
+	tracer.EndArrayDecode(ctx, err)
+}
+
+// startArrayEncode is the EncodeText/EncodeBinary counterpart of
+// startArrayDecode.
+func startArrayEncode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context {
+	if tracer == nil {
+		return ctx
+	}
+	return tracer.StartArrayEncode(ctx, elementOID, dimensions, elementCount)
+}
+
+// endArrayEncode is the EndArrayEncode counterpart of startArrayEncode.
+func endArrayEncode(ctx context.Context, err error) {
+	if tracer == nil {
+		return
+	}
+	tracer.EndArrayEncode(ctx, err)
+}
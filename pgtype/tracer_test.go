@@ -0,0 +1,78 @@
+package pgtype
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStartEndArrayDecodeNoTracer(t *testing.T) {
+	SetTracer(nil)
+
+	ctx := startArrayDecode(context.Background(), 1, 1, 1)
+	if ctx != context.Background() {
+		t.Fatal("startArrayDecode with no tracer should return ctx unchanged")
+	}
+
+	// Must not panic with no tracer registered.
+	endArrayDecode(ctx, errors.New("boom"))
+}
+
+type spyTracer struct {
+	startDecodeCalls int
+	endDecodeCalls   int
+	startEncodeCalls int
+	endEncodeCalls   int
+	lastErr          error
+}
+
+func (s *spyTracer) StartArrayDecode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context {
+	s.startDecodeCalls++
+	return ctx
+}
+
+func (s *spyTracer) EndArrayDecode(ctx context.Context, err error) {
+	s.endDecodeCalls++
+	s.lastErr = err
+}
+
+func (s *spyTracer) StartArrayEncode(ctx context.Context, elementOID uint32, dimensions, elementCount int) context.Context {
+	s.startEncodeCalls++
+	return ctx
+}
+
+func (s *spyTracer) EndArrayEncode(ctx context.Context, err error) {
+	s.endEncodeCalls++
+	s.lastErr = err
+}
+
+func TestArrayDecodeDispatchesToRegisteredTracer(t *testing.T) {
+	spy := &spyTracer{}
+	SetTracer(spy)
+	defer SetTracer(nil)
+
+	ctx := startArrayDecode(context.Background(), InetOID, 1, 3)
+	endArrayDecode(ctx, nil)
+
+	if spy.startDecodeCalls != 1 || spy.endDecodeCalls != 1 {
+		t.Fatalf("tracer calls = %+v, want one start and one end", spy)
+	}
+}
+
+type ctxReader struct {
+	ctx context.Context
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) { return 0, nil }
+func (r *ctxReader) Context() context.Context   { return r.ctx }
+
+func TestReaderContextUsesContextReader(t *testing.T) {
+	want := context.WithValue(context.Background(), ctxKeyTest{}, "value")
+	r := &ctxReader{ctx: want}
+
+	if got := readerContext(r); got != want {
+		t.Fatalf("readerContext() = %v, want %v", got, want)
+	}
+}
+
+type ctxKeyTest struct{}